@@ -12,357 +12,214 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestCreate(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedResult int64
-		entity         *assignment.Assignment
-		expectedError  error
-	}{
-		{
-			name:           "create assignment Success",
-			expectedResult: 1,
-			entity:         &assignment.Assignment{},
-			expectedError:  nil,
-		},
-		{
-			name:           "create assignment Error",
-			expectedResult: 0,
-			entity:         &assignment.Assignment{},
-			expectedError:  fmt.Errorf("error"),
-		},
-	}
+// errKind, storeCase and runStoreCases are duplicated in the schedulerimpl
+// store tests; keep both copies in sync until they move into dbtesting.
+type errKind int
+
+const (
+	errNone errKind = iota
+	errNotFound
+	errOther
+)
 
-	for _, tc := range testCases {
+type storeCase[I, R any] struct {
+	name     string
+	in       I
+	dbResult interface{}
+	dbError  error
+	want     R
+	wantErr  errKind
+}
+
+func runStoreCases[I, R any](t *testing.T, cases []storeCase[I, R], call func(s *store, ctx context.Context, in I) (R, error)) {
+	t.Helper()
+
+	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError: tc.expectedError,
+				ExpectedError:  tc.dbError,
+				ExpectedResult: tc.dbResult,
 			}
 
-			store := newStore(fakeDB)
-
-			_, err := store.create(context.Background(), tc.entity)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error %v", err)
+			result, err := call(newStore(fakeDB), context.Background(), tc.in)
+			switch tc.wantErr {
+			case errNone:
+				require.NoError(t, err)
+			case errNotFound:
+				require.ErrorIs(t, err, sql.ErrNoRows)
+			case errOther:
+				require.Error(t, err)
+				require.NotErrorIs(t, err, sql.ErrNoRows)
 			}
+
+			require.Equal(t, tc.want, result)
 		})
 	}
 }
 
-func TestCreateAssignmentLog(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult interface{}
-		entity         *assignment.AssignmentLog
-	}{
+func TestCreate(t *testing.T) {
+	runStoreCases(t, []storeCase[*assignment.Assignment, struct{}]{
 		{
-			name:           "create assignment log Success",
-			expectedError:  nil,
-			expectedResult: 1,
-			entity:         &assignment.AssignmentLog{},
+			name: "create assignment Success",
+			in:   &assignment.Assignment{},
 		},
 		{
-			name:           "create assignment log Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: 0,
-			entity:         &assignment.AssignmentLog{},
+			name:    "create assignment Error",
+			in:      &assignment.Assignment{},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
+	}, func(s *store, ctx context.Context, in *assignment.Assignment) (struct{}, error) {
+		_, err := s.create(ctx, in)
+		return struct{}{}, err
+	})
+}
 
-			err := store.createAssignmentLog(context.Background(), tc.entity)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpacted error %v", err)
-			}
-		})
-	}
+func TestCreateAssignmentLog(t *testing.T) {
+	runStoreCases(t, []storeCase[*assignment.AssignmentLog, struct{}]{
+		{
+			name:     "create assignment log Success",
+			in:       &assignment.AssignmentLog{},
+			dbResult: 1,
+		},
+		{
+			name:     "create assignment log Error",
+			in:       &assignment.AssignmentLog{},
+			dbResult: 0,
+			dbError:  fmt.Errorf("error"),
+			wantErr:  errOther,
+		},
+	}, func(s *store, ctx context.Context, in *assignment.AssignmentLog) (struct{}, error) {
+		return struct{}{}, s.createAssignmentLog(ctx, in)
+	})
 }
 
 func TestGetByMemberID(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult *assignment.AssignmentDTO
-		memberID       int64
-	}{
+	runStoreCases(t, []storeCase[int64, *assignment.AssignmentDTO]{
 		{
-			name:           "Success",
-			expectedError:  nil,
-			expectedResult: &assignment.AssignmentDTO{},
-			memberID:       1,
+			name:     "Success",
+			in:       1,
+			dbResult: &assignment.AssignmentDTO{},
+			want:     &assignment.AssignmentDTO{},
 		},
 		{
-			name:           "Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			memberID:       1,
+			name:    "Error",
+			in:      1,
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
 		{
-			name:           "Not Found",
-			expectedError:  sql.ErrNoRows,
-			expectedResult: nil,
-			memberID:       1,
+			name:    "Not Found",
+			in:      1,
+			dbError: sql.ErrNoRows,
+			wantErr: errNotFound,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.getByMemberID(context.Background(), tc.memberID)
-			if err == nil && result != nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
-
+	}, (*store).getByMemberID)
 }
 
 func TestGetByID(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult *assignment.AssignmentDTO
-		assignmentID   int64
-	}{
+	runStoreCases(t, []storeCase[int64, *assignment.AssignmentDTO]{
 		{
-			name:           "get assignment by id Success",
-			expectedError:  nil,
-			expectedResult: &assignment.AssignmentDTO{},
-			assignmentID:   1,
+			name:     "get assignment by id Success",
+			in:       1,
+			dbResult: &assignment.AssignmentDTO{},
+			want:     &assignment.AssignmentDTO{},
 		},
 		{
-			name:           "get assignment by id Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			assignmentID:   1,
+			name:    "get assignment by id Error",
+			in:      1,
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
 		{
-			name:           "get assigment by id Error- Not Found",
-			expectedError:  sql.ErrNoRows,
-			expectedResult: nil,
-			assignmentID:   1,
+			name:    "get assignment by id Error - Not Found",
+			in:      1,
+			dbError: sql.ErrNoRows,
+			wantErr: errNotFound,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.getByID(context.Background(), tc.assignmentID)
-			if err == nil && result != nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
+	}, (*store).getByID)
 }
 
 func TestSearch(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		result         []*assignment.Assignment
-		expectedResult *assignment.SearchAssignmentQueryResult
-		query          *assignment.SearchAssignmentQuery
-	}{
+	runStoreCases(t, []storeCase[*assignment.SearchAssignmentQuery, *assignment.SearchAssignmentQueryResult]{
 		{
-			name:          "Success",
-			expectedError: nil,
-			result:        []*assignment.Assignment{},
-			expectedResult: &assignment.SearchAssignmentQueryResult{
-				Assignments: []*assignment.Assignment{},
-			},
-			query: &assignment.SearchAssignmentQuery{
+			name: "Success",
+			in: &assignment.SearchAssignmentQuery{
 				MemberID:  1,
 				Assignees: []int64{1},
 				DateFrom:  &time.Time{},
 				DateTo:    &time.Time{},
 				PerPage:   10,
 			},
+			dbResult: &assignment.SearchAssignmentQueryResult{
+				Assignments: []*assignment.Assignment{},
+			},
+			want: &assignment.SearchAssignmentQueryResult{
+				Assignments: []*assignment.Assignment{},
+			},
 		},
 		{
-			name:           "Error",
-			query:          &assignment.SearchAssignmentQuery{},
-			expectedResult: nil,
-			expectedError:  fmt.Errorf("error"),
+			name:    "Error",
+			in:      &assignment.SearchAssignmentQuery{},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.search(context.Background(), tc.query)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
+	}, (*store).search)
 }
 
 func TestGetByAssigneesID(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult []int64
-		assignmentID   []int64
-	}{
+	runStoreCases(t, []storeCase[[]int64, []int64]{
 		{
-			name:           "Success",
-			expectedError:  nil,
-			expectedResult: []int64{1},
-			assignmentID:   []int64{1},
+			name:     "Success",
+			in:       []int64{1},
+			dbResult: []int64{1},
+			want:     []int64{1},
 		},
 		{
-			name:           "Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			assignmentID:   []int64{1},
+			name:    "Error",
+			in:      []int64{1},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
 		{
-			name:           "Not Found",
-			expectedError:  sql.ErrNoRows,
-			expectedResult: nil,
-			assignmentID:   []int64{1},
+			name:    "Not Found",
+			in:      []int64{1},
+			dbError: sql.ErrNoRows,
+			wantErr: errNotFound,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.getByAssigneesID(context.Background(), tc.assignmentID)
-			if err == nil && result != nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
-
+	}, (*store).getByAssigneesID)
 }
 
 func TestUpdate(t *testing.T) {
-	testCases := []struct {
-		name          string
-		expectedError error
-		entity        *assignment.Assignment
-	}{
+	runStoreCases(t, []storeCase[*assignment.Assignment, struct{}]{
 		{
-			name:          "Success",
-			expectedError: nil,
-			entity:        &assignment.Assignment{},
+			name: "Success",
+			in:   &assignment.Assignment{},
 		},
 		{
-			name:          "Error",
-			expectedError: fmt.Errorf("error"),
-			entity:        &assignment.Assignment{},
+			name:    "Error",
+			in:      &assignment.Assignment{},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError: tc.expectedError,
-			}
-
-			store := newStore(fakeDB)
-
-			err := store.update(context.Background(), tc.entity)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-		})
-	}
+	}, func(s *store, ctx context.Context, in *assignment.Assignment) (struct{}, error) {
+		return struct{}{}, s.update(ctx, in)
+	})
 }
 
 func TestGetAssignmentLog(t *testing.T) {
-	testCases := []struct {
-		name           string
-		id             int64
-		expectedResult []*assignment.AssignmentLog
-		expectedError  error
-	}{
+	runStoreCases(t, []storeCase[int64, []*assignment.AssignmentLog]{
 		{
-			name:           "Success",
-			expectedError:  nil,
-			expectedResult: []*assignment.AssignmentLog{},
-			id:             1,
+			name:     "Success",
+			in:       1,
+			dbResult: []*assignment.AssignmentLog{},
+			want:     []*assignment.AssignmentLog{},
 		},
 		{
-			name:           "Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			id:             1,
+			name:    "Error",
+			in:      1,
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.getAssignmentLog(context.Background(), tc.id)
-			if err == nil && result != nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpectred error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
+	}, (*store).getAssignmentLog)
 }
@@ -5,113 +5,103 @@ import (
 	"database/sql"
 	"fmt"
 	dbtesting "main/pkg/infra/storage/db/testing"
-	"main/pkg/infra/storage/postgres"
 	"main/pkg/tsm/scheduler"
 	"main/pkg/util/pointer"
-	"reflect"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
-	"go.uber.org/zap"
 )
 
-func TestCreate(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedResult int64
-		entity         *scheduler.Scheduler
-		expectedError  error
-	}{
-		{
-			name:           "create scheduler Success",
-			expectedResult: 1,
-			entity:         &scheduler.Scheduler{},
-			expectedError:  nil,
-		},
-		{
-			name:           "create scheduler Error",
-			expectedResult: 0,
-			entity:         &scheduler.Scheduler{},
-			expectedError:  fmt.Errorf("error"),
-		},
-	}
+// errKind, storeCase and runStoreCases are duplicated in the assignmentimpl
+// store tests; keep both copies in sync until they move into dbtesting.
+type errKind int
+
+const (
+	errNone errKind = iota
+	errNotFound
+	errOther
+)
+
+type storeCase[I, R any] struct {
+	name     string
+	in       I
+	dbResult interface{}
+	dbError  error
+	want     R
+	wantErr  errKind
+}
 
-	for _, tc := range testCases {
+func runStoreCases[I, R any](t *testing.T, cases []storeCase[I, R], call func(s *store, ctx context.Context, in I) (R, error)) {
+	t.Helper()
+
+	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
+				ExpectedError:  tc.dbError,
+				ExpectedResult: tc.dbResult,
 			}
 
-			store := newStore(fakeDB)
-
-			_, err := store.create(context.Background(), tc.entity)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
+			result, err := call(newStore(fakeDB), context.Background(), tc.in)
+			switch tc.wantErr {
+			case errNone:
+				require.NoError(t, err)
+			case errNotFound:
+				require.ErrorIs(t, err, sql.ErrNoRows)
+			case errOther:
+				require.Error(t, err)
+				require.NotErrorIs(t, err, sql.ErrNoRows)
 			}
+
+			require.Equal(t, tc.want, result)
 		})
 	}
 }
 
-func TestCreateAssignee(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult interface{}
-		entity         *scheduler.SchedulerAssignee
-	}{
+func TestCreate(t *testing.T) {
+	runStoreCases(t, []storeCase[*scheduler.Scheduler, struct{}]{
 		{
-			name:           "create scheduler assignee Success",
-			expectedError:  nil,
-			expectedResult: 1,
-			entity:         &scheduler.SchedulerAssignee{},
+			name:     "create scheduler Success",
+			in:       &scheduler.Scheduler{},
+			dbResult: int64(1),
 		},
 		{
-			name:           "create assigment log Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: 0,
-			entity:         &scheduler.SchedulerAssignee{},
+			name:     "create scheduler Error",
+			in:       &scheduler.Scheduler{},
+			dbResult: int64(0),
+			dbError:  fmt.Errorf("error"),
+			wantErr:  errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
+	}, func(s *store, ctx context.Context, in *scheduler.Scheduler) (struct{}, error) {
+		_, err := s.create(ctx, in)
+		return struct{}{}, err
+	})
+}
 
-			err := store.createAssignee(context.Background(), tc.entity)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-		})
-	}
+func TestCreateAssignee(t *testing.T) {
+	runStoreCases(t, []storeCase[*scheduler.SchedulerAssignee, struct{}]{
+		{
+			name:     "create scheduler assignee Success",
+			in:       &scheduler.SchedulerAssignee{},
+			dbResult: 1,
+		},
+		{
+			name:     "create scheduler assignee Error",
+			in:       &scheduler.SchedulerAssignee{},
+			dbResult: 0,
+			dbError:  fmt.Errorf("error"),
+			wantErr:  errOther,
+		},
+	}, func(s *store, ctx context.Context, in *scheduler.SchedulerAssignee) (struct{}, error) {
+		return struct{}{}, s.createAssignee(ctx, in)
+	})
 }
 
 func TestSearch(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		result         []*scheduler.Scheduler
-		query          *scheduler.SearchSchedulerQuery
-		expectedResult *scheduler.SearchSchedulerQueryResult
-	}{
+	runStoreCases(t, []storeCase[*scheduler.SearchSchedulerQuery, *scheduler.SearchSchedulerQueryResult]{
 		{
-			name:          "Success",
-			expectedError: nil,
-			result:        []*scheduler.Scheduler{},
-			expectedResult: &scheduler.SearchSchedulerQueryResult{
-				Schedulers: []*scheduler.Scheduler{},
-			},
-			query: &scheduler.SearchSchedulerQuery{
+			name: "Success",
+			in: &scheduler.SearchSchedulerQuery{
 				Name:      "name",
 				Currency:  "currency",
 				Priority:  1,
@@ -121,402 +111,171 @@ func TestSearch(t *testing.T) {
 				DateTo:    &time.Time{},
 				PerPage:   10,
 			},
+			dbResult: &scheduler.SearchSchedulerQueryResult{
+				Schedulers: []*scheduler.Scheduler{},
+			},
+			want: &scheduler.SearchSchedulerQueryResult{
+				Schedulers: []*scheduler.Scheduler{},
+			},
 		},
 		{
-			name:           "Error",
-			query:          &scheduler.SearchSchedulerQuery{},
-			expectedResult: nil,
-			expectedError:  fmt.Errorf("error"),
+			name:    "Error",
+			in:      &scheduler.SearchSchedulerQuery{},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.search(context.Background(), tc.query)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
+	}, (*store).search)
 }
 
 func TestGetSchedulerByID(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult *scheduler.SchedulerDTO
-		schedulerID    int64
-	}{
+	runStoreCases(t, []storeCase[int64, *scheduler.SchedulerDTO]{
 		{
-			name:           "get assigment by id Success",
-			expectedError:  nil,
-			expectedResult: &scheduler.SchedulerDTO{},
-			schedulerID:    1,
+			name:     "get scheduler by id Success",
+			in:       1,
+			dbResult: &scheduler.SchedulerDTO{},
+			want:     &scheduler.SchedulerDTO{},
 		},
 		{
-			name:           "get assigment by id Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			schedulerID:    1,
+			name:    "get scheduler by id Error",
+			in:      1,
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
 		{
-			name:           "get assigment by id Error - Not Found",
-			expectedError:  sql.ErrNoRows,
-			expectedResult: nil,
-			schedulerID:    1,
+			name:    "get scheduler by id Error - Not Found",
+			in:      1,
+			dbError: sql.ErrNoRows,
+			wantErr: errNotFound,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.GetSchedulerByID(context.Background(), tc.schedulerID)
-			if err == nil && result != nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
+	}, (*store).GetSchedulerByID)
 }
 
 func TestGetSchedulerAssignByID(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult []*scheduler.SchedulerAssignee
-		assignID       int64
-	}{
+	runStoreCases(t, []storeCase[int64, []*scheduler.SchedulerAssignee]{
 		{
-			name:           "Success",
-			expectedError:  nil,
-			expectedResult: []*scheduler.SchedulerAssignee{},
-			assignID:       1,
+			name:     "Success",
+			in:       1,
+			dbResult: []*scheduler.SchedulerAssignee{},
+			want:     []*scheduler.SchedulerAssignee{},
 		},
 		{
-			name:           "Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			assignID:       1,
+			name:    "Error",
+			in:      1,
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
 		{
-			name:           "Not Found",
-			expectedError:  sql.ErrNoRows,
-			expectedResult: nil,
-			assignID:       1,
+			name:    "Not Found",
+			in:      1,
+			dbError: sql.ErrNoRows,
+			wantErr: errNotFound,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.GetSchedulerAssignByID(context.Background(), tc.assignID)
-			if err == nil && result != nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
+	}, (*store).GetSchedulerAssignByID)
 }
 
 func TestGetSchedulerUserIDsByID(t *testing.T) {
-	testCases := []struct {
-		name           string
-		userIDsByID    []int64
-		expectedResult []*int64
-		expectedError  error
-	}{
+	runStoreCases(t, []storeCase[int64, []*int64]{
 		{
-			name:           "Success",
-			userIDsByID:    []int64{1},
-			expectedResult: []*int64{pointer.Int64Ptr(1)},
-			expectedError:  nil,
+			name:     "Success",
+			in:       1,
+			dbResult: []*int64{pointer.Int64Ptr(1)},
+			want:     []*int64{pointer.Int64Ptr(1)},
 		},
 		{
-			name:           "Error",
-			userIDsByID:    []int64{0},
-			expectedResult: nil,
-			expectedError:  fmt.Errorf("error"),
+			name:    "Error",
+			in:      0,
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedResult: tc.expectedResult,
-				ExpectedError:  tc.expectedError,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.GetSchedulerUserIDsByID(context.Background(), tc.userIDsByID[0])
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
+	}, (*store).GetSchedulerUserIDsByID)
 }
 
 func TestUpdateScheduleStatus(t *testing.T) {
-	testCases := []struct {
-		name           string
-		expectedError  error
-		expectedResult interface{}
-		entity         *scheduler.SchedulerDTO
-	}{
+	runStoreCases(t, []storeCase[*scheduler.SchedulerDTO, struct{}]{
 		{
-			name:           "update scheduler status Success",
-			expectedError:  nil,
-			expectedResult: nil,
-			entity:         &scheduler.SchedulerDTO{},
+			name: "update scheduler status Success",
+			in:   &scheduler.SchedulerDTO{},
 		},
 		{
-			name:           "update scheduler status Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			entity:         &scheduler.SchedulerDTO{},
+			name:    "update scheduler status Error",
+			in:      &scheduler.SchedulerDTO{},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			err := store.updateScheduleStatus(context.Background(), tc.entity)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-		})
-	}
+	}, func(s *store, ctx context.Context, in *scheduler.SchedulerDTO) (struct{}, error) {
+		return struct{}{}, s.updateScheduleStatus(ctx, in)
+	})
 }
 
 func TestUnassignAssignee(t *testing.T) {
-	testCases := []struct {
-		name          string
-		id            int64
-		expectedError error
-	}{
+	runStoreCases(t, []storeCase[int64, struct{}]{
 		{
-			name:          "unassign assignee Success",
-			id:            1,
-			expectedError: nil,
+			name: "unassign assignee Success",
+			in:   1,
 		},
 		{
-			name:          " unassign assignee Error",
-			id:            1,
-			expectedError: fmt.Errorf("error"),
+			name:    "unassign assignee Error",
+			in:      1,
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError: tc.expectedError,
-			}
-
-			store := newStore(fakeDB)
-
-			err := store.unassignAssignee(context.Background(), tc.id)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-		})
-	}
+	}, func(s *store, ctx context.Context, id int64) (struct{}, error) {
+		return struct{}{}, s.unassignAssignee(ctx, id)
+	})
 }
 
 func TestUpdateScheduler(t *testing.T) {
-	testCases := []struct {
-		name          string
-		expectedError error
-		entity        *scheduler.Scheduler
-	}{
+	runStoreCases(t, []storeCase[*scheduler.Scheduler, struct{}]{
 		{
-			name:          "Success",
-			expectedError: nil,
-			entity:        &scheduler.Scheduler{},
+			name: "Success",
+			in:   &scheduler.Scheduler{},
 		},
 		{
-			name:          "Error",
-			expectedError: fmt.Errorf("error"),
-			entity:        &scheduler.Scheduler{},
+			name:    "Error",
+			in:      &scheduler.Scheduler{},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError: tc.expectedError,
-			}
-
-			store := newStore(fakeDB)
-
-			err := store.updateScheduler(context.Background(), tc.entity)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-		})
-	}
+	}, func(s *store, ctx context.Context, in *scheduler.Scheduler) (struct{}, error) {
+		return struct{}{}, s.updateScheduler(ctx, in)
+	})
 }
 
 func TestDeleteBySchedulerIDAndUserID(t *testing.T) {
-	testCases := []struct {
-		name          string
-		id            int64
-		userID        int64
-		expectedError error
-	}{
+	type ids struct {
+		schedulerID int64
+		userID      int64
+	}
+
+	runStoreCases(t, []storeCase[ids, struct{}]{
 		{
-			name:          "Success",
-			id:            1,
-			userID:        1,
-			expectedError: nil,
+			name: "Success",
+			in:   ids{schedulerID: 1, userID: 1},
 		},
 		{
-			name:          "Error",
-			id:            1,
-			userID:        1,
-			expectedError: fmt.Errorf("error"),
+			name:    "Error",
+			in:      ids{schedulerID: 1, userID: 1},
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError: tc.expectedError,
-			}
-
-			store := newStore(fakeDB)
-
-			err := store.deleteBySchedulerIDAndUserID(context.Background(), tc.id, tc.userID)
-			if err == nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-
-		})
-	}
+	}, func(s *store, ctx context.Context, in ids) (struct{}, error) {
+		return struct{}{}, s.deleteBySchedulerIDAndUserID(ctx, in.schedulerID, in.userID)
+	})
 }
 
 func TestGetSchedulerList(t *testing.T) {
-	testCases := []struct {
-		name           string
-		id             int64
-		expectedResult []*scheduler.SchedulerDTO
-		expectedError  error
-	}{
+	runStoreCases(t, []storeCase[struct{}, []*scheduler.SchedulerDTO]{
 		{
-			name:           "Success",
-			expectedError:  nil,
-			expectedResult: []*scheduler.SchedulerDTO{},
-			id:             1,
+			name:     "Success",
+			dbResult: []*scheduler.SchedulerDTO{},
+			want:     []*scheduler.SchedulerDTO{},
 		},
 		{
-			name:           "Error",
-			expectedError:  fmt.Errorf("error"),
-			expectedResult: nil,
-			id:             1,
+			name:    "Error",
+			dbError: fmt.Errorf("error"),
+			wantErr: errOther,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			fakeDB := &dbtesting.FakeSqlxdb{
-				ExpectedError:  tc.expectedError,
-				ExpectedResult: tc.expectedResult,
-			}
-
-			store := newStore(fakeDB)
-
-			result, err := store.getSchedulerList(context.Background())
-			if err == nil && result != nil && tc.expectedError != nil {
-				t.Fatalf("expected error %q, but got none", tc.expectedError)
-			} else if err != nil && tc.expectedError == nil {
-				t.Fatalf("unexpectred error: %v", err)
-			}
-
-			require.Equal(t, tc.expectedResult, result)
-		})
-	}
-}
-
-func Test_store_GetSchedulerUserIDsByID(t *testing.T) {
-	type fields struct {
-		db     postgres.DB
-		logger *zap.Logger
-	}
-	type args struct {
-		ctx         context.Context
-		schedulerID int64
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    []*int64
-		wantErr bool
-	}{
-		// TODO: Add test cases.
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			s := &store{
-				db:     tt.fields.db,
-				logger: tt.fields.logger,
-			}
-			got, err := s.GetSchedulerUserIDsByID(tt.args.ctx, tt.args.schedulerID)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("store.GetSchedulerUserIDsByID() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("store.GetSchedulerUserIDsByID() = %v, want %v", got, tt.want)
-			}
-		})
-	}
+	}, func(s *store, ctx context.Context, _ struct{}) ([]*scheduler.SchedulerDTO, error) {
+		return s.getSchedulerList(ctx)
+	})
 }